@@ -0,0 +1,149 @@
+// Package grpc provides a gRPC transport for addsvc, a sibling to the
+// existing HTTP transport in addsvc/pkg/http.
+package grpc
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pb"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+// grpcServer implements pb.AddServer on top of a set of endpoints.
+type grpcServer struct {
+	sum    grpctransport.Handler
+	concat grpctransport.Handler
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC AddServer.
+func NewGRPCServer(endpoints endpoints.Endpoints, tracer stdopentracing.Tracer, logger log.Logger) pb.AddServer {
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerErrorLogger(logger),
+	}
+	return &grpcServer{
+		sum: grpctransport.NewServer(
+			endpoints.SumEndpoint,
+			decodeGRPCSumRequest,
+			encodeGRPCSumResponse,
+			append(options, grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "Sum", logger)))...,
+		),
+		concat: grpctransport.NewServer(
+			endpoints.ConcatEndpoint,
+			decodeGRPCConcatRequest,
+			encodeGRPCConcatResponse,
+			append(options, grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "Concat", logger)))...,
+		),
+	}
+}
+
+func (s *grpcServer) Sum(ctx context.Context, req *pb.SumRequest) (*pb.SumReply, error) {
+	_, rep, err := s.sum.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*pb.SumReply), nil
+}
+
+func (s *grpcServer) Concat(ctx context.Context, req *pb.ConcatRequest) (*pb.ConcatReply, error) {
+	_, rep, err := s.concat.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*pb.ConcatReply), nil
+}
+
+// NewGRPCClient returns a service.Service backed by a gRPC client connection.
+// It is meant to be used by programs that will eventually run as clients to
+// an addsvc gRPC server.
+func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger log.Logger) service.Service {
+	options := []grpctransport.ClientOption{}
+	var sumEndpoint = grpctransport.NewClient(
+		conn,
+		"pb.AddService",
+		"Sum",
+		encodeGRPCSumRequest,
+		decodeGRPCSumResponse,
+		pb.SumReply{},
+		append(options, grpctransport.ClientBefore(opentracing.ContextToGRPC(tracer, logger)))...,
+	).Endpoint()
+
+	var concatEndpoint = grpctransport.NewClient(
+		conn,
+		"pb.AddService",
+		"Concat",
+		encodeGRPCConcatRequest,
+		decodeGRPCConcatResponse,
+		pb.ConcatReply{},
+		append(options, grpctransport.ClientBefore(opentracing.ContextToGRPC(tracer, logger)))...,
+	).Endpoint()
+
+	return endpoints.Endpoints{
+		SumEndpoint:    sumEndpoint,
+		ConcatEndpoint: concatEndpoint,
+	}
+}
+
+func decodeGRPCSumRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SumRequest)
+	return endpoints.SumRequest{A: int(req.A), B: int(req.B)}, nil
+}
+
+func decodeGRPCConcatRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ConcatRequest)
+	return endpoints.ConcatRequest{A: req.A, B: req.B}, nil
+}
+
+func decodeGRPCSumResponse(_ context.Context, grpcReply interface{}) (interface{}, error) {
+	reply := grpcReply.(*pb.SumReply)
+	return endpoints.SumResponse{V: int(reply.V), Err: reply.Err}, nil
+}
+
+func decodeGRPCConcatResponse(_ context.Context, grpcReply interface{}) (interface{}, error) {
+	reply := grpcReply.(*pb.ConcatReply)
+	return endpoints.ConcatResponse{V: reply.V, Err: reply.Err}, nil
+}
+
+func encodeGRPCSumRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(endpoints.SumRequest)
+	return &pb.SumRequest{A: int64(req.A), B: int64(req.B)}, nil
+}
+
+func encodeGRPCConcatRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req := request.(endpoints.ConcatRequest)
+	return &pb.ConcatRequest{A: req.A, B: req.B}, nil
+}
+
+func encodeGRPCSumResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	resp := response.(endpoints.SumResponse)
+	if resp.Err != "" {
+		return nil, err2grpc(resp.Err)
+	}
+	return &pb.SumReply{V: int64(resp.V)}, nil
+}
+
+func encodeGRPCConcatResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	resp := response.(endpoints.ConcatResponse)
+	if resp.Err != "" {
+		return nil, err2grpc(resp.Err)
+	}
+	return &pb.ConcatReply{V: resp.V}, nil
+}
+
+// err2grpc maps the well-known addsvc validation errors to gRPC status
+// errors, mirroring the err2code mapping used by the HTTP transport.
+func err2grpc(msg string) error {
+	switch msg {
+	case service.ErrTwoZeroes.Error(), service.ErrMaxSizeExceeded.Error(), service.ErrIntOverflow.Error():
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return status.Error(codes.Internal, msg)
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pb"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+func TestDecodeGRPCSumRequest(t *testing.T) {
+	got, err := decodeGRPCSumRequest(context.Background(), &pb.SumRequest{A: 1, B: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := endpoints.SumRequest{A: 1, B: 2}
+	if got != want {
+		t.Errorf("decodeGRPCSumRequest = %+v, want %+v", got, want)
+	}
+}
+
+func TestEncodeGRPCSumRequest(t *testing.T) {
+	got, err := encodeGRPCSumRequest(context.Background(), endpoints.SumRequest{A: 3, B: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, ok := got.(*pb.SumRequest)
+	if !ok {
+		t.Fatalf("encodeGRPCSumRequest returned %T, want *pb.SumRequest", got)
+	}
+	if req.A != 3 || req.B != 4 {
+		t.Errorf("encodeGRPCSumRequest = %+v, want A=3 B=4", req)
+	}
+}
+
+func TestEncodeGRPCSumResponse(t *testing.T) {
+	got, err := encodeGRPCSumResponse(context.Background(), endpoints.SumResponse{V: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reply, ok := got.(*pb.SumReply)
+	if !ok {
+		t.Fatalf("encodeGRPCSumResponse returned %T, want *pb.SumReply", got)
+	}
+	if reply.V != 7 {
+		t.Errorf("encodeGRPCSumResponse V = %d, want 7", reply.V)
+	}
+}
+
+func TestEncodeGRPCSumResponseError(t *testing.T) {
+	_, err := encodeGRPCSumResponse(context.Background(), endpoints.SumResponse{Err: service.ErrTwoZeroes.Error()})
+	if err == nil {
+		t.Fatal("expected an error for a response carrying Err, got nil")
+	}
+}
+
+func TestDecodeGRPCConcatRequest(t *testing.T) {
+	got, err := decodeGRPCConcatRequest(context.Background(), &pb.ConcatRequest{A: "foo", B: "bar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := endpoints.ConcatRequest{A: "foo", B: "bar"}
+	if got != want {
+		t.Errorf("decodeGRPCConcatRequest = %+v, want %+v", got, want)
+	}
+}
+
+func TestErr2GRPC(t *testing.T) {
+	cases := []struct {
+		msg      string
+		wantCode codes.Code
+	}{
+		{service.ErrTwoZeroes.Error(), codes.InvalidArgument},
+		{service.ErrMaxSizeExceeded.Error(), codes.InvalidArgument},
+		{service.ErrIntOverflow.Error(), codes.InvalidArgument},
+		{"some unrelated failure", codes.Internal},
+	}
+	for _, c := range cases {
+		err := err2grpc(c.msg)
+		if status.Code(err) != c.wantCode {
+			t.Errorf("err2grpc(%q) code = %v, want %v", c.msg, status.Code(err), c.wantCode)
+		}
+	}
+}
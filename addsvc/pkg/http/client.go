@@ -0,0 +1,126 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/circuitbreaker"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/ratelimit"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/tracing/zipkin"
+	httptransport "github.com/go-kit/kit/transport/http"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	stdzipkin "github.com/openzipkin/zipkin-go"
+	"github.com/sony/gobreaker"
+	"golang.org/x/time/rate"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+// ClientOptions configures the resiliency middleware installed by
+// NewHTTPClient. The zero value is not valid; use DefaultClientOptions to
+// get the package defaults, then tune individual fields per deployment.
+type ClientOptions struct {
+	// Every and Burst configure the per-endpoint rate.NewLimiter used to
+	// reject requests, rather than queue them, once the bucket is empty.
+	Every time.Duration
+	Burst int
+
+	// Breaker configures the gobreaker.Settings used for the per-endpoint
+	// circuit breaker. Name is overridden per endpoint regardless of what
+	// is set here.
+	Breaker gobreaker.Settings
+}
+
+// DefaultClientOptions returns the resiliency settings used when no
+// ClientOptions are supplied to NewHTTPClient: a 1-per-second/100-burst
+// token bucket and a gobreaker with its own defaults.
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Every: time.Second,
+		Burst: 100,
+	}
+}
+
+// NewHTTPClient returns a service.Service backed by an HTTP client, with a
+// rate limiter and circuit breaker installed in front of every endpoint.
+// instance may be either a bare "host:port" or a full URL; either way the
+// client fills in the well-known /sum and /concat paths. opts is optional;
+// when omitted, DefaultClientOptions is used. zipkinTracer may be nil, in
+// which case no Zipkin tracing is installed; the two tracers coexist so
+// that callers can migrate from OpenTracing to Zipkin incrementally.
+func NewHTTPClient(instance string, tracer stdopentracing.Tracer, zipkinTracer *stdzipkin.Tracer, logger log.Logger, opts ...ClientOptions) (service.Service, error) {
+	options := DefaultClientOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := []httptransport.ClientOption{
+		httptransport.ClientBefore(opentracing.ContextToHTTP(tracer, logger)),
+	}
+	if zipkinTracer != nil {
+		clientOptions = append(clientOptions, zipkin.HTTPClientTrace(zipkinTracer))
+	}
+
+	var sumEndpoint endpoint.Endpoint
+	{
+		sumURL := *u
+		sumURL.Path = "/sum"
+		sumEndpoint = httptransport.NewClient(
+			"POST",
+			&sumURL,
+			EncodeGenericRequest,
+			DecodeSumResponse,
+			clientOptions...,
+		).Endpoint()
+		sumEndpoint = rateLimit(options)(sumEndpoint)
+		sumEndpoint = breaker("Sum", options)(sumEndpoint)
+	}
+
+	var concatEndpoint endpoint.Endpoint
+	{
+		concatURL := *u
+		concatURL.Path = "/concat"
+		concatEndpoint = httptransport.NewClient(
+			"POST",
+			&concatURL,
+			EncodeGenericRequest,
+			DecodeConcatResponse,
+			clientOptions...,
+		).Endpoint()
+		concatEndpoint = rateLimit(options)(concatEndpoint)
+		concatEndpoint = breaker("Concat", options)(concatEndpoint)
+	}
+
+	return endpoints.Endpoints{
+		SumEndpoint:    sumEndpoint,
+		ConcatEndpoint: concatEndpoint,
+	}, nil
+}
+
+// rateLimit builds a token-bucket rate limiting middleware from the given
+// options, erroring rather than blocking once the bucket is empty.
+func rateLimit(options ClientOptions) endpoint.Middleware {
+	limiter := rate.NewLimiter(rate.Every(options.Every), options.Burst)
+	return ratelimit.NewErroringLimiter(limiter)
+}
+
+// breaker builds a circuit breaker middleware named after the endpoint it
+// guards, using the given options' breaker settings.
+func breaker(name string, options ClientOptions) endpoint.Middleware {
+	settings := options.Breaker
+	settings.Name = name
+	return circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(settings))
+}
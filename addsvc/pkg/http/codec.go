@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pb"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeMsgpack  = "application/msgpack"
+)
+
+// Codec marshals and unmarshals the addsvc request/response types to and
+// from a particular wire format, and reports the Content-Type that
+// identifies it.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	ContentType() string
+}
+
+// codecs is the registry of codecs NewHandler and NewHTTPClient negotiate
+// over, keyed by the Content-Type/Accept token that selects them.
+var codecs = map[string]Codec{
+	contentTypeJSON:     jsonCodec{},
+	contentTypeProtobuf: protobufCodec{},
+	contentTypeMsgpack:  msgpackCodec{},
+}
+
+// negotiateCodec picks a Codec based on header (Content-Type on a request,
+// Accept on a response), falling back to JSON when header is empty, unknown,
+// or "*/*".
+func negotiateCodec(header string) Codec {
+	if header != "" && header != "*/*" {
+		if mediaType, _, err := mime.ParseMediaType(header); err == nil {
+			if codec, ok := codecs[mediaType]; ok {
+				return codec
+			}
+		}
+	}
+	return jsonCodec{}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) ContentType() string                        { return contentTypeJSON }
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error)      { return msgpack.Marshal(v) }
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error { return msgpack.Unmarshal(data, v) }
+func (msgpackCodec) ContentType() string                        { return contentTypeMsgpack }
+
+// protobufCodec marshals the domain SumRequest/SumResponse/ConcatRequest/
+// ConcatResponse types by converting them to their pb counterparts from
+// the gRPC transport and delegating to proto.Marshal/proto.Unmarshal.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string { return contentTypeProtobuf }
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, err := toProtoMessage(v)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, err := toProtoMessage(v)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	return fromProtoMessage(msg, v)
+}
+
+// toProtoMessage converts a domain request/response (passed by value, as
+// produced by an endpoint, or by pointer, as decoded into) into its pb
+// wire counterpart.
+func toProtoMessage(v interface{}) (proto.Message, error) {
+	switch t := v.(type) {
+	case endpoints.SumRequest:
+		return &pb.SumRequest{A: int64(t.A), B: int64(t.B)}, nil
+	case *endpoints.SumRequest:
+		return &pb.SumRequest{A: int64(t.A), B: int64(t.B)}, nil
+	case endpoints.SumResponse:
+		return &pb.SumReply{V: int64(t.V), Err: t.Err}, nil
+	case *endpoints.SumResponse:
+		return &pb.SumReply{V: int64(t.V), Err: t.Err}, nil
+	case endpoints.ConcatRequest:
+		return &pb.ConcatRequest{A: t.A, B: t.B}, nil
+	case *endpoints.ConcatRequest:
+		return &pb.ConcatRequest{A: t.A, B: t.B}, nil
+	case endpoints.ConcatResponse:
+		return &pb.ConcatReply{V: t.V, Err: t.Err}, nil
+	case *endpoints.ConcatResponse:
+		return &pb.ConcatReply{V: t.V, Err: t.Err}, nil
+	case errorWrapper:
+		return &errorReply{Error: t.Error}, nil
+	case *errorWrapper:
+		return &errorReply{Error: t.Error}, nil
+	default:
+		return nil, errUnsupportedProtobufType(v)
+	}
+}
+
+func fromProtoMessage(msg proto.Message, v interface{}) error {
+	switch t := v.(type) {
+	case *endpoints.SumRequest:
+		m := msg.(*pb.SumRequest)
+		t.A, t.B = int(m.A), int(m.B)
+	case *endpoints.SumResponse:
+		m := msg.(*pb.SumReply)
+		t.V, t.Err = int(m.V), m.Err
+	case *endpoints.ConcatRequest:
+		m := msg.(*pb.ConcatRequest)
+		t.A, t.B = m.A, m.B
+	case *endpoints.ConcatResponse:
+		m := msg.(*pb.ConcatReply)
+		t.V, t.Err = m.V, m.Err
+	case *errorWrapper:
+		m := msg.(*errorReply)
+		t.Error = m.Error
+	default:
+		return errUnsupportedProtobufType(v)
+	}
+	return nil
+}
+
+func errUnsupportedProtobufType(v interface{}) error {
+	return fmt.Errorf("protobuf codec: unsupported type %T", v)
+}
+
+// errorReply is the protobuf wire shape of errorWrapper; the error body
+// isn't part of the addsvc IDL, so it isn't generated into addsvc/pb.
+type errorReply struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *errorReply) Reset()         { *m = errorReply{} }
+func (m *errorReply) String() string { return fmt.Sprintf("%+v", *m) }
+func (*errorReply) ProtoMessage()    {}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+)
+
+func TestNegotiateCodec(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"", contentTypeJSON},
+		{"*/*", contentTypeJSON},
+		{"application/json", contentTypeJSON},
+		{"application/x-protobuf", contentTypeProtobuf},
+		{"application/msgpack", contentTypeMsgpack},
+		{"application/xml", contentTypeJSON}, // unknown falls back to JSON
+	}
+	for _, c := range cases {
+		if got := negotiateCodec(c.header).ContentType(); got != c.want {
+			t.Errorf("negotiateCodec(%q).ContentType() = %q, want %q", c.header, got, c.want)
+		}
+	}
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	want := endpoints.SumRequest{A: 1, B: 2}
+	data, err := jsonCodec{}.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got endpoints.SumRequest
+	if err := jsonCodec{}.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != want {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestMsgpackCodecRoundTrip(t *testing.T) {
+	want := endpoints.ConcatRequest{A: "foo", B: "bar"}
+	data, err := msgpackCodec{}.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got endpoints.ConcatRequest
+	if err := msgpackCodec{}.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != want {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestProtobufCodecRoundTrip(t *testing.T) {
+	want := endpoints.SumRequest{A: 5, B: 6}
+	data, err := protobufCodec{}.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got endpoints.SumRequest
+	if err := protobufCodec{}.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != want {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestProtobufCodecUnsupportedType(t *testing.T) {
+	if _, err := protobufCodec{}.Marshal(struct{ X int }{1}); err == nil {
+		t.Fatal("expected an error marshaling an unsupported type, got nil")
+	}
+}
+
+func TestErrorEncoderRespectsNegotiatedCodec(t *testing.T) {
+	for _, header := range []string{"application/json", "application/x-protobuf", "application/msgpack"} {
+		r := httptest.NewRequest("POST", "/sum", nil)
+		r.Header.Set("Accept", header)
+		rec := httptest.NewRecorder()
+		errorEncoder(codecToContext(context.Background(), r), errors.New("boom"), rec)
+		if got := rec.Header().Get("Content-Type"); got != header {
+			t.Fatalf("Content-Type(%s) = %q, want %q", header, got, header)
+		}
+		var w errorWrapper
+		if err := negotiateCodec(header).Unmarshal(rec.Body.Bytes(), &w); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", header, err)
+		}
+		if w.Error != "boom" {
+			t.Errorf("%s round trip = %q, want %q", header, w.Error, "boom")
+		}
+	}
+}
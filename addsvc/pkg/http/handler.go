@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/tracing/zipkin"
 	httptransport "github.com/go-kit/kit/transport/http"
 	stdopentracing "github.com/opentracing/opentracing-go"
+	stdzipkin "github.com/openzipkin/zipkin-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/net/context"
 
@@ -19,12 +22,21 @@ import (
 )
 
 // NewHandler returns a handler that makes a set of endpoints available on
-// predefined paths.
-func NewHandler(ctx context.Context, endpoints endpoints.Endpoints, logger log.Logger, trace stdopentracing.Tracer) http.Handler {
+// predefined paths. zipkinTracer may be nil, in which case no Zipkin
+// tracing is installed; the two tracers coexist so that callers can migrate
+// from OpenTracing to Zipkin incrementally.
+func NewHandler(ctx context.Context, endpoints endpoints.Endpoints, logger log.Logger, trace stdopentracing.Tracer, zipkinTracer *stdzipkin.Tracer) http.Handler {
 	options := []httptransport.ServerOption{
 		httptransport.ServerErrorEncoder(errorEncoder),
 		httptransport.ServerErrorLogger(logger),
 	}
+	if zipkinTracer != nil {
+		// Zipkin HTTP Server Trace can either be instantiated per endpoint
+		// with a specific operation name, or globally, in which case the
+		// operation name will be the HTTP method. We use the latter.
+		options = append(options, zipkin.HTTPServerTrace(zipkinTracer))
+	}
+	options = append(options, httptransport.ServerBefore(codecToContext))
 	m := http.NewServeMux()
 	m.Handle("/sum", httptransport.NewServer(
 		ctx,
@@ -44,9 +56,34 @@ func NewHandler(ctx context.Context, endpoints endpoints.Endpoints, logger log.L
 	return m
 }
 
-func errorEncoder(_ context.Context, err error, w http.ResponseWriter) {
+// codecContextKey is the context key codecToContext stashes the negotiated
+// response Codec under, so that EncodeGenericResponse and errorEncoder -
+// which never see the original *http.Request - can retrieve it.
+type codecContextKey struct{}
+
+// codecToContext is a transport/http.RequestFunc that negotiates a response
+// Codec from the request's Accept header and carries it on the context for
+// the rest of the request's lifetime.
+func codecToContext(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, codecContextKey{}, negotiateCodec(r.Header.Get("Accept")))
+}
+
+func codecFromContext(ctx context.Context) Codec {
+	if codec, ok := ctx.Value(codecContextKey{}).(Codec); ok {
+		return codec
+	}
+	return jsonCodec{}
+}
+
+func errorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	codec := codecFromContext(ctx)
+	w.Header().Set("Content-Type", codec.ContentType())
 	w.WriteHeader(err2code(err))
-	json.NewEncoder(w).Encode(errorWrapper{Error: err.Error()})
+	body, encErr := codec.Marshal(errorWrapper{Error: err.Error()})
+	if encErr != nil {
+		body, _ = jsonCodec{}.Marshal(errorWrapper{Error: err.Error()})
+	}
+	w.Write(body)
 }
 
 func err2code(err error) int {
@@ -68,7 +105,7 @@ func err2code(err error) int {
 
 func errorDecoder(r *http.Response) error {
 	var w errorWrapper
-	if err := json.NewDecoder(r.Body).Decode(&w); err != nil {
+	if err := decodeBody(r.Header.Get("Content-Type"), r.Body, &w); err != nil {
 		return err
 	}
 	return errors.New(w.Error)
@@ -78,49 +115,61 @@ type errorWrapper struct {
 	Error string `json:"error"`
 }
 
-// DecodeSumRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded sum request from the HTTP request body. Primarily useful in a
-// server.
+// decodeBody reads body and unmarshals it into v using the codec selected
+// by header (a Content-Type), falling back to JSON.
+func decodeBody(header string, body io.Reader, v interface{}) error {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	return negotiateCodec(header).Unmarshal(data, v)
+}
+
+// DecodeSumRequest is a transport/http.DecodeRequestFunc that decodes a sum
+// request from the HTTP request body, honoring the request's Content-Type
+// and falling back to JSON. Primarily useful in a server.
 func DecodeSumRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	var req endpoints.SumRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err := decodeBody(r.Header.Get("Content-Type"), r.Body, &req)
 	return req, err
 }
 
 // DecodeConcatRequest is a transport/http.DecodeRequestFunc that decodes a
-// JSON-encoded concat request from the HTTP request body. Primarily useful in a
-// server.
+// concat request from the HTTP request body, honoring the request's
+// Content-Type and falling back to JSON. Primarily useful in a server.
 func DecodeConcatRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	var req endpoints.ConcatRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err := decodeBody(r.Header.Get("Content-Type"), r.Body, &req)
 	return req, err
 }
 
 // DecodeSumResponse is a transport/http.DecodeResponseFunc that decodes a
-// JSON-encoded sum response from the HTTP response body. If the response has a
-// non-200 status code, we will interpret that as an error and attempt to decode
-// the specific error message from the response body. Primarily useful in a
-// client.
+// sum response from the HTTP response body, dispatching to the codec named
+// by the response's Content-Type and falling back to JSON. If the response
+// has a non-200 status code, we will interpret that as an error and attempt
+// to decode the specific error message from the response body. Primarily
+// useful in a client.
 func DecodeSumResponse(_ context.Context, r *http.Response) (interface{}, error) {
 	if r.StatusCode != http.StatusOK {
-		return nil, errors.New(r.Status)
+		return nil, errorDecoder(r)
 	}
 	var resp endpoints.SumResponse
-	err := json.NewDecoder(r.Body).Decode(&resp)
+	err := decodeBody(r.Header.Get("Content-Type"), r.Body, &resp)
 	return resp, err
 }
 
-// DecodeConcatResponse is a transport/http.DecodeResponseFunc that decodes
-// a JSON-encoded concat response from the HTTP response body. If the response
-// has a non-200 status code, we will interpret that as an error and attempt to
-// decode the specific error message from the response body. Primarily useful in
-// a client.
+// DecodeConcatResponse is a transport/http.DecodeResponseFunc that decodes a
+// concat response from the HTTP response body, dispatching to the codec
+// named by the response's Content-Type and falling back to JSON. If the
+// response has a non-200 status code, we will interpret that as an error and
+// attempt to decode the specific error message from the response body.
+// Primarily useful in a client.
 func DecodeConcatResponse(_ context.Context, r *http.Response) (interface{}, error) {
 	if r.StatusCode != http.StatusOK {
-		return nil, errors.New(r.Status)
+		return nil, errorDecoder(r)
 	}
 	var resp endpoints.ConcatResponse
-	err := json.NewDecoder(r.Body).Decode(&resp)
+	err := decodeBody(r.Header.Get("Content-Type"), r.Body, &resp)
 	return resp, err
 }
 
@@ -136,11 +185,19 @@ func EncodeGenericRequest(_ context.Context, r *http.Request, request interface{
 }
 
 // EncodeGenericResponse is a transport/http.EncodeResponseFunc that encodes
-// the response as JSON to the response writer. Primarily useful in a server.
+// the response using the codec negotiated from the request's Accept header
+// (see codecToContext), falling back to JSON. Primarily useful in a server.
 func EncodeGenericResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	if f, ok := response.(endpoints.Failer); ok && f.Failed() != nil {
 		errorEncoder(ctx, f.Failed(), w)
 		return nil
 	}
-	return json.NewEncoder(w).Encode(response)
+	codec := codecFromContext(ctx)
+	body, err := codec.Marshal(response)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", codec.ContentType())
+	_, err = w.Write(body)
+	return err
 }
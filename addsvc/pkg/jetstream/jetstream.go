@@ -0,0 +1,243 @@
+// Package jetstream provides an asynchronous, NATS JetStream-backed
+// transport for addsvc, a sibling to the HTTP, gRPC and Thrift transports.
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nats-io/nats.go"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+const (
+	sumSubject    = "addsvc.sum"
+	concatSubject = "addsvc.concat"
+
+	sumDurable    = "addsvc-sum"
+	concatDurable = "addsvc-concat"
+
+	defaultMaxDeliveries = 5
+
+	// fetchBackoff throttles the pull loop after an unexpected (non-timeout)
+	// Fetch error, so a persistently unreachable server doesn't spin it hot.
+	fetchBackoff = 250 * time.Millisecond
+)
+
+// errorWrapper mirrors the wrapper used by the HTTP transport, so the same
+// error shape travels over every transport.
+type errorWrapper struct {
+	Error string `json:"error"`
+}
+
+// HandlerOptions configures NewJetStreamHandler's consumers. The zero value
+// is not valid; use DefaultHandlerOptions to get the package default, then
+// tune MaxDeliveries per deployment.
+type HandlerOptions struct {
+	// MaxDeliveries bounds how many times a message is delivered, counting
+	// the first delivery, before JetStream stops redelivering it after
+	// retryable processing failures.
+	MaxDeliveries int
+}
+
+// DefaultHandlerOptions returns the settings used when no HandlerOptions
+// are supplied to NewJetStreamHandler.
+func DefaultHandlerOptions() HandlerOptions {
+	return HandlerOptions{MaxDeliveries: defaultMaxDeliveries}
+}
+
+// NewJetStreamHandler creates durable pull consumers for the Sum and
+// Concat endpoints on the addsvc.sum and addsvc.concat subjects. Each
+// message is decoded from JSON, passed to the corresponding endpoint, and
+// the JSON-encoded response is published - over core NATS, not JetStream -
+// to the reply subject carried in the message's Nats-Reply-Subject header.
+// The consumer goroutines run until ctx is canceled; callers are expected
+// to invoke this once at startup and cancel ctx at shutdown. opts is
+// optional; when omitted, DefaultHandlerOptions is used.
+func NewJetStreamHandler(ctx context.Context, nc *nats.Conn, js nats.JetStreamContext, endpoints endpoints.Endpoints, logger log.Logger, opts ...HandlerOptions) error {
+	options := DefaultHandlerOptions()
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	if err := newConsumer(ctx, nc, js, sumSubject, sumDurable, logger, options, func(ctx context.Context, payload []byte) ([]byte, bool, error) {
+		var req endpoints.SumRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, true, err
+		}
+		resp, err := endpoints.SumEndpoint(ctx, req)
+		if err != nil {
+			return nil, isValidationError(err), err
+		}
+		reply, err := json.Marshal(resp)
+		return reply, false, err
+	}); err != nil {
+		return err
+	}
+	if err := newConsumer(ctx, nc, js, concatSubject, concatDurable, logger, options, func(ctx context.Context, payload []byte) ([]byte, bool, error) {
+		var req endpoints.ConcatRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, true, err
+		}
+		resp, err := endpoints.ConcatEndpoint(ctx, req)
+		if err != nil {
+			return nil, isValidationError(err), err
+		}
+		reply, err := json.Marshal(resp)
+		return reply, false, err
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isValidationError reports whether err is one of the addsvc domain
+// validation errors, which no redelivery of the same message can fix.
+func isValidationError(err error) bool {
+	return err == service.ErrTwoZeroes || err == service.ErrMaxSizeExceeded || err == service.ErrIntOverflow
+}
+
+// newConsumer creates a durable, explicit-ack pull consumer on subject and
+// starts a goroutine that fetches and handles messages with handle until ctx
+// is canceled. handle reports, alongside its error, whether that error is
+// permanent (the message can never succeed, e.g. it failed to decode or
+// failed domain validation) as opposed to retryable; only retryable
+// failures are Nak'd for redelivery; permanent failures are terminated (Term)
+// once their error reply has been sent; so a message is retried only when there's a
+// chance a future delivery attempt could succeed.
+func newConsumer(ctx context.Context, nc *nats.Conn, js nats.JetStreamContext, subject, durable string, logger log.Logger, options HandlerOptions, handle func(context.Context, []byte) ([]byte, bool, error)) error {
+	sub, err := js.PullSubscribe(subject, durable, nats.AckExplicit(), nats.MaxDeliver(options.MaxDeliveries))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			msgs, err := sub.Fetch(1, nats.MaxWait(time.Second))
+			if err != nil {
+				if errors.Is(err, nats.ErrTimeout) {
+					continue
+				}
+				logger.Log("transport", "jetstream", "subject", subject, "err", err)
+				time.Sleep(fetchBackoff)
+				continue
+			}
+
+			for _, msg := range msgs {
+				reply, permanent, err := handle(ctx, msg.Data)
+				if err != nil {
+					reply, _ = json.Marshal(errorWrapper{Error: err.Error()})
+				}
+
+				var publishErr error
+				if replyTo := msg.Header.Get("Nats-Reply-Subject"); replyTo != "" {
+					publishErr = nc.Publish(replyTo, reply)
+					if publishErr != nil {
+						logger.Log("transport", "jetstream", "subject", subject, "reply_to", replyTo, "err", publishErr)
+					}
+				}
+
+				switch {
+				case err == nil && publishErr == nil:
+					msg.Ack()
+				case err != nil && permanent:
+					// The input can never succeed; redelivering it would
+					// just repeat the same failure and reply.
+					msg.Term()
+				default:
+					// Either the endpoint failed in a way that might not
+					// recur, or the reply never made it to the client;
+					// both are worth retrying.
+					msg.Nak()
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// jetStreamClient adapts publish/subscribe round trips over JetStream to a
+// service.Service. Requests are durable, published through js; replies are
+// ephemeral and exchanged over core NATS, since no stream is declared to
+// cover the per-request reply inbox.
+type jetStreamClient struct {
+	nc      *nats.Conn
+	js      nats.JetStreamContext
+	timeout time.Duration
+}
+
+// NewJetStreamClient returns a service.Service that publishes requests to
+// addsvc.sum/addsvc.concat and awaits the response on a unique reply inbox,
+// timing out after timeout.
+func NewJetStreamClient(nc *nats.Conn, js nats.JetStreamContext, timeout time.Duration) service.Service {
+	return &jetStreamClient{nc: nc, js: js, timeout: timeout}
+}
+
+func (c *jetStreamClient) Sum(ctx context.Context, a, b int) (int, error) {
+	req := endpoints.SumRequest{A: a, B: b}
+	var resp endpoints.SumResponse
+	if err := c.roundTrip(sumSubject, req, &resp); err != nil {
+		return 0, err
+	}
+	if resp.Err != "" {
+		return 0, errors.New(resp.Err)
+	}
+	return resp.V, nil
+}
+
+func (c *jetStreamClient) Concat(ctx context.Context, a, b string) (string, error) {
+	req := endpoints.ConcatRequest{A: a, B: b}
+	var resp endpoints.ConcatResponse
+	if err := c.roundTrip(concatSubject, req, &resp); err != nil {
+		return "", err
+	}
+	if resp.Err != "" {
+		return "", errors.New(resp.Err)
+	}
+	return resp.V, nil
+}
+
+func (c *jetStreamClient) roundTrip(subject string, request, response interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	inbox := nats.NewInbox()
+	sub, err := c.nc.SubscribeSync(inbox)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	msg := nats.NewMsg(subject)
+	msg.Data = payload
+	msg.Header.Set("Nats-Reply-Subject", inbox)
+	if _, err := c.js.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	reply, err := sub.NextMsg(c.timeout)
+	if err != nil {
+		return fmt.Errorf("waiting for reply on %s: %w", inbox, err)
+	}
+
+	var wrapped errorWrapper
+	if err := json.Unmarshal(reply.Data, &wrapped); err == nil && wrapped.Error != "" {
+		return errors.New(wrapped.Error)
+	}
+	return json.Unmarshal(reply.Data, response)
+}
@@ -0,0 +1,98 @@
+// Package thrift provides a Thrift transport for addsvc, a sibling to the
+// existing HTTP and gRPC transports.
+package thrift
+
+import (
+	"context"
+	"errors"
+
+	addthrift "github.com/peterbourgon/go-microservices/addsvc/thrift/gen-go/addsvc"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+// thriftServer adapts a set of endpoints to the generated
+// addthrift.AddService interface.
+type thriftServer struct {
+	ctx       context.Context
+	endpoints endpoints.Endpoints
+}
+
+// NewThriftServer makes a set of endpoints available as a Thrift
+// AddService.
+func NewThriftServer(endpoints endpoints.Endpoints) addthrift.AddService {
+	return &thriftServer{
+		ctx:       context.Background(),
+		endpoints: endpoints,
+	}
+}
+
+func (s *thriftServer) Sum(ctx context.Context, a int64, b int64) (*addthrift.SumReply, error) {
+	request := endpoints.SumRequest{A: int(a), B: int(b)}
+	response, err := s.endpoints.SumEndpoint(ctx, request)
+	if err != nil {
+		return &addthrift.SumReply{Err: err.Error()}, nil
+	}
+	resp := response.(endpoints.SumResponse)
+	return &addthrift.SumReply{Value: int64(resp.V), Err: resp.Err}, nil
+}
+
+func (s *thriftServer) Concat(ctx context.Context, a string, b string) (*addthrift.ConcatReply, error) {
+	request := endpoints.ConcatRequest{A: a, B: b}
+	response, err := s.endpoints.ConcatEndpoint(ctx, request)
+	if err != nil {
+		return &addthrift.ConcatReply{Err: err.Error()}, nil
+	}
+	resp := response.(endpoints.ConcatResponse)
+	return &addthrift.ConcatReply{Value: resp.V, Err: resp.Err}, nil
+}
+
+// thriftClient adapts a generated addthrift.AddServiceClient to a
+// service.Service.
+type thriftClient struct {
+	client *addthrift.AddServiceClient
+}
+
+// NewThriftClient returns a service.Service backed by a Thrift client
+// connection.
+func NewThriftClient(client *addthrift.AddServiceClient) service.Service {
+	return &thriftClient{client: client}
+}
+
+func (c *thriftClient) Sum(ctx context.Context, a, b int) (int, error) {
+	reply, err := c.client.Sum(ctx, int64(a), int64(b))
+	if err != nil {
+		return 0, err
+	}
+	if reply.Err != "" {
+		return 0, errFromThrift(reply.Err)
+	}
+	return int(reply.Value), nil
+}
+
+func (c *thriftClient) Concat(ctx context.Context, a, b string) (string, error) {
+	reply, err := c.client.Concat(ctx, a, b)
+	if err != nil {
+		return "", err
+	}
+	if reply.Err != "" {
+		return "", errFromThrift(reply.Err)
+	}
+	return reply.Value, nil
+}
+
+// errFromThrift maps the well-known addsvc validation errors back to their
+// sentinel values: the same errors that err2code and err2grpc single out in
+// the HTTP and gRPC transports. Other messages become plain errors.
+func errFromThrift(msg string) error {
+	switch msg {
+	case service.ErrTwoZeroes.Error():
+		return service.ErrTwoZeroes
+	case service.ErrMaxSizeExceeded.Error():
+		return service.ErrMaxSizeExceeded
+	case service.ErrIntOverflow.Error():
+		return service.ErrIntOverflow
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,31 @@
+package thrift
+
+import (
+	"testing"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+)
+
+func TestErrFromThrift(t *testing.T) {
+	sentinels := []error{
+		service.ErrTwoZeroes,
+		service.ErrMaxSizeExceeded,
+		service.ErrIntOverflow,
+	}
+	for _, want := range sentinels {
+		if got := errFromThrift(want.Error()); got != want {
+			t.Errorf("errFromThrift(%q) = %v, want %v", want.Error(), got, want)
+		}
+	}
+}
+
+func TestErrFromThriftUnknown(t *testing.T) {
+	const msg = "some unrelated failure"
+	err := errFromThrift(msg)
+	if err == service.ErrTwoZeroes || err == service.ErrMaxSizeExceeded || err == service.ErrIntOverflow {
+		t.Fatalf("errFromThrift(%q) unexpectedly matched a sentinel", msg)
+	}
+	if err.Error() != msg {
+		t.Errorf("errFromThrift(%q).Error() = %q, want %q", msg, err.Error(), msg)
+	}
+}
@@ -0,0 +1,149 @@
+// Command addsvc runs the addsvc service, serving its endpoints over
+// multiple transports simultaneously.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/go-kit/kit/log"
+	"github.com/nats-io/nats.go"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	stdzipkin "github.com/openzipkin/zipkin-go"
+	zipkinhttp "github.com/openzipkin/zipkin-go/reporter/http"
+	"google.golang.org/grpc"
+
+	"github.com/peterbourgon/go-microservices/addsvc/pb"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/endpoints"
+	addgrpc "github.com/peterbourgon/go-microservices/addsvc/pkg/grpc"
+	addhttp "github.com/peterbourgon/go-microservices/addsvc/pkg/http"
+	addjetstream "github.com/peterbourgon/go-microservices/addsvc/pkg/jetstream"
+	"github.com/peterbourgon/go-microservices/addsvc/pkg/service"
+	addthriftsvc "github.com/peterbourgon/go-microservices/addsvc/pkg/thrift"
+	addthrift "github.com/peterbourgon/go-microservices/addsvc/thrift/gen-go/addsvc"
+
+	"golang.org/x/net/context"
+)
+
+func main() {
+	fs := flag.NewFlagSet("addsvc", flag.ExitOnError)
+	var (
+		httpAddr   = fs.String("http-addr", ":8080", "HTTP listen address")
+		grpcAddr   = fs.String("grpc-addr", ":8081", "gRPC listen address")
+		thriftAddr = fs.String("thrift-addr", ":8082", "Thrift listen address")
+		zipkinURL  = fs.String("zipkin-url", "", "Enable Zipkin tracing via a collector URL e.g. http://localhost:9411/api/v2/spans")
+		natsURL    = fs.String("nats-url", "", "Enable the JetStream transport via a NATS server URL e.g. nats://localhost:4222")
+		natsStream = fs.String("nats-stream", "ADDSVC", "JetStream stream name used by the JetStream transport")
+	)
+	fs.Parse(os.Args[1:])
+
+	var logger log.Logger
+	logger = log.NewLogfmtLogger(os.Stderr)
+	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	logger = log.With(logger, "caller", log.DefaultCaller)
+
+	var tracer stdopentracing.Tracer
+	tracer = stdopentracing.GlobalTracer()
+
+	var zipkinTracer *stdzipkin.Tracer
+	if *zipkinURL != "" {
+		reporter := zipkinhttp.NewReporter(*zipkinURL)
+		endpoint, err := stdzipkin.NewEndpoint("addsvc", *httpAddr)
+		if err != nil {
+			logger.Log("err", err)
+			os.Exit(1)
+		}
+		zipkinTracer, err = stdzipkin.NewTracer(reporter, stdzipkin.WithLocalEndpoint(endpoint))
+		if err != nil {
+			logger.Log("err", err)
+			os.Exit(1)
+		}
+	}
+
+	var (
+		svc = service.New(logger)
+		eps = endpoints.New(svc, logger)
+		ctx = context.Background()
+	)
+
+	errs := make(chan error, 5)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	go func() {
+		logger.Log("transport", "HTTP", "addr", *httpAddr)
+		handler := addhttp.NewHandler(ctx, eps, logger, tracer, zipkinTracer)
+		errs <- http.ListenAndServe(*httpAddr, handler)
+	}()
+
+	go func() {
+		logger.Log("transport", "gRPC", "addr", *grpcAddr)
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			errs <- err
+			return
+		}
+		srv := addgrpc.NewGRPCServer(eps, tracer, logger)
+		baseServer := grpc.NewServer()
+		pb.RegisterAddServer(baseServer, srv)
+		errs <- baseServer.Serve(listener)
+	}()
+
+	go func() {
+		logger.Log("transport", "Thrift", "addr", *thriftAddr)
+		var protocolFactory thrift.TProtocolFactory
+		protocolFactory = thrift.NewTBinaryProtocolFactoryDefault()
+		transportFactory := thrift.NewTBufferedTransportFactory(8192)
+		transport, err := thrift.NewTServerSocket(*thriftAddr)
+		if err != nil {
+			errs <- err
+			return
+		}
+		srv := addthriftsvc.NewThriftServer(eps)
+		server := thrift.NewTSimpleServer4(
+			addthrift.NewAddServiceProcessor(srv),
+			transport,
+			transportFactory,
+			protocolFactory,
+		)
+		errs <- server.Serve()
+	}()
+
+	if *natsURL != "" {
+		go func() {
+			logger.Log("transport", "JetStream", "url", *natsURL, "stream", *natsStream)
+			nc, err := nats.Connect(*natsURL)
+			if err != nil {
+				errs <- err
+				return
+			}
+			js, err := nc.JetStream()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err := js.AddStream(&nats.StreamConfig{
+				Name:     *natsStream,
+				Subjects: []string{"addsvc.*"},
+			}); err != nil {
+				errs <- err
+				return
+			}
+			if err := addjetstream.NewJetStreamHandler(ctx, nc, js, eps, logger); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	logger.Log("exit", <-errs)
+}